@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ryanjoneil/2023-pydata-global-order-up/former/schedule/internal/human"
+)
+
+// blockReport captures everything the text/table formatters need about one
+// scheduled block, alongside what already goes into the JSON output.
+type blockReport struct {
+	Date           string
+	Block          string
+	Start          time.Time
+	End            time.Time
+	RequiredCount  int
+	SimulatedCount int
+}
+
+// countCoveringWorkers returns how many workers have at least one shift
+// overlapping [start, end).
+func countCoveringWorkers(workers []worker, start, end time.Time) (int, error) {
+	count := 0
+	for _, w := range workers {
+		for _, s := range w.Availability {
+			shiftStart, err := time.Parse(time.RFC3339, s.Start)
+			if err != nil {
+				return 0, err
+			}
+			shiftEnd, err := time.Parse(time.RFC3339, s.End)
+			if err != nil {
+				return 0, err
+			}
+			if shiftStart.Before(end) && start.Before(shiftEnd) {
+				count++
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+// writeReport renders blockReports as "text" (one summary line per block)
+// or "table" (aligned columns), using human-formatted durations, counts,
+// and coverage percentages.
+func writeReport(w io.Writer, format string, reports []blockReport) error {
+	switch format {
+	case "text":
+		for _, r := range reports {
+			gap := r.SimulatedCount - r.RequiredCount
+			fmt.Fprintf(w, "%s %-7s %s-%s  duration=%s  required=%s  simulated=%s  coverage=%s (%+d)\n",
+				r.Date, r.Block,
+				r.Start.Format("15:04"), r.End.Format("15:04"),
+				human.Duration(r.End.Sub(r.Start)),
+				human.Count(r.RequiredCount),
+				human.Count(r.SimulatedCount),
+				coveragePercent(r),
+				gap,
+			)
+		}
+		return nil
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+		fmt.Fprintln(tw, "DATE\tBLOCK\tSTART\tEND\tDURATION\tREQUIRED\tSIMULATED\tCOVERAGE\tGAP/SURPLUS")
+		for _, r := range reports {
+			gap := r.SimulatedCount - r.RequiredCount
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%+d\n",
+				r.Date, r.Block,
+				r.Start.Format("15:04"), r.End.Format("15:04"),
+				human.Duration(r.End.Sub(r.Start)),
+				human.Count(r.RequiredCount),
+				human.Count(r.SimulatedCount),
+				coveragePercent(r),
+				gap,
+			)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unrecognized -format %q: expected json, text, or table", format)
+	}
+}
+
+// coveragePercent is the simulated worker count as a fraction of required,
+// formatted as a percentage. A block that requires zero workers is fully
+// covered by definition.
+func coveragePercent(r blockReport) string {
+	if r.RequiredCount == 0 {
+		return human.Percent(1)
+	}
+	return human.Percent(float64(r.SimulatedCount) / float64(r.RequiredCount))
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var defaultAdjectives = []string{"Recalcitrant", "Beneficent", "Flabbergasted", "Loquacious", "Mellifluous", "Cantankerous", "Effervescent", "Quixotic", "Gregarious", "Lugubrious", "Obstreperous", "Perspicacious", "Rambunctious", "Sesquipedalian", "Vivacious", "Whimsical", "Zany", "Astonishing", "Bombastic", "Cacophonous", "Dapper", "Ebullient", "Farcical", "Gibbous", "Hapless", "Ineffable", "Jocular", "Kaleidoscopic", "Lachrymose", "Munificent", "Nebulous", "Omnipotent", "Pernicious", "Querulous", "Rapscallion", "Supercilious", "Turbulent", "Ubiquitous", "Vainglorious", "Wanderlust", "Xenophilic", "Yawning", "Zealous", "Aberrant", "Bucolic", "Cryptic", "Delirious", "Enigmatic", "Frivolous", "Ghastly"}
+var defaultAnimals = []string{"Lemur", "Hippopotamus", "Platypus", "Sloth", "Armadillo", "Kangaroo", "Penguin", "Otter", "Meerkat", "Wombat", "Koala", "Chameleon", "Pangolin", "Ostrich", "Flamingo", "Narwhal", "Toucan", "Aardvark", "Porcupine", "Walrus", "Capuchin Monkey", "Bush Baby", "Giraffe", "Okapi", "Red Panda", "Fennec Fox", "Axolotl", "Tarsier", "Sugar Glider", "Quokka", "Blobfish", "Peacock", "Mandrill", "Proboscis Monkey", "Dik-dik", "Jerboa", "Alpaca", "Flying Squirrel", "Sea Otter", "Manatee", "Kakapo", "Binturong", "Tapir", "Guinea Pig", "Hedgehog", "Star-Nosed Mole", "Turtle", "Chinchilla", "Naked Mole Rat"}
+
+func toSlug(s string) string {
+	slug := strings.ToLower(s)
+	reg := regexp.MustCompile("[^a-z0-9-]+")
+	slug = reg.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "-")
+}
+
+// IDGenerator hands out adjective-animal worker IDs (e.g. "happy-otter")
+// drawn without replacement from the cartesian product of its wordlists, so
+// the first N IDs from a given seed are guaranteed unique in O(1) per call.
+// Once the pool is exhausted it falls back to a numeric suffix rather than
+// looping forever.
+type IDGenerator struct {
+	rng   *rand.Rand
+	pairs []string
+	next  int
+	dupes map[string]int
+}
+
+// IDGeneratorOption configures an IDGenerator at construction time.
+type IDGeneratorOption func(*IDGenerator)
+
+// WithWordlists replaces the default adjective and animal wordlists, e.g.
+// ones loaded from a JSON or YAML file.
+func WithWordlists(adjectives, animals []string) IDGeneratorOption {
+	return func(g *IDGenerator) {
+		g.pairs = cartesian(adjectives, animals)
+	}
+}
+
+// Wordlist holds a custom adjective/animal pool for WithWordlists, loaded
+// from a JSON or YAML file via LoadWordlist.
+type Wordlist struct {
+	Adjectives []string `yaml:"adjectives" json:"adjectives"`
+	Animals    []string `yaml:"animals" json:"animals"`
+}
+
+// LoadWordlist reads a custom adjective/animal wordlist from a JSON or YAML
+// file, selected by the file's extension.
+func LoadWordlist(path string) (*Wordlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading wordlist %s: %w", path, err)
+	}
+
+	wl := &Wordlist{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, wl); err != nil {
+			return nil, fmt.Errorf("parsing wordlist %s as YAML: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, wl); err != nil {
+			return nil, fmt.Errorf("parsing wordlist %s as JSON: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized wordlist extension %q: expected .yaml, .yml, or .json", ext)
+	}
+
+	if len(wl.Adjectives) == 0 || len(wl.Animals) == 0 {
+		return nil, fmt.Errorf("wordlist %s must declare at least one adjective and one animal", path)
+	}
+	return wl, nil
+}
+
+// resolveIDOpts loads a custom wordlist from path, if given, and returns the
+// IDGeneratorOption needed to use it. An empty path returns no options, so
+// NewIDGenerator falls back to the built-in wordlists.
+func resolveIDOpts(path string) ([]IDGeneratorOption, error) {
+	if path == "" {
+		return nil, nil
+	}
+	wl, err := LoadWordlist(path)
+	if err != nil {
+		return nil, err
+	}
+	return []IDGeneratorOption{WithWordlists(wl.Adjectives, wl.Animals)}, nil
+}
+
+// NewIDGenerator builds an IDGenerator whose pair order is a Fisher-Yates
+// shuffle of the wordlist cartesian product, seeded by seed so the
+// sequence is reproducible.
+func NewIDGenerator(seed int64, opts ...IDGeneratorOption) *IDGenerator {
+	g := &IDGenerator{
+		rng:   rand.New(rand.NewSource(seed)),
+		pairs: cartesian(defaultAdjectives, defaultAnimals),
+		dupes: map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if len(g.pairs) == 0 {
+		panic("idgen: wordlists produce an empty pool; WithWordlists needs at least one adjective and one animal")
+	}
+
+	for i := len(g.pairs) - 1; i > 0; i-- {
+		j := g.rng.Intn(i + 1)
+		g.pairs[i], g.pairs[j] = g.pairs[j], g.pairs[i]
+	}
+	return g
+}
+
+// Next returns the next worker ID. IDs are unique until the wordlist pool
+// is exhausted, after which a numeric suffix (e.g. "happy-otter-2") is
+// appended to keep generating unique IDs indefinitely.
+func (g *IDGenerator) Next() string {
+	if g.next < len(g.pairs) {
+		id := g.pairs[g.next]
+		g.next++
+		return id
+	}
+
+	id := g.pairs[g.rng.Intn(len(g.pairs))]
+	g.dupes[id]++
+	return fmt.Sprintf("%s-%d", id, g.dupes[id]+1)
+}
+
+func cartesian(adjectives, animals []string) []string {
+	pairs := make([]string, 0, len(adjectives)*len(animals))
+	for _, adjective := range adjectives {
+		for _, animal := range animals {
+			pairs = append(pairs, toSlug(fmt.Sprintf("%s %s", adjective, animal)))
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIDGeneratorUniqueUntilPoolExhausted(t *testing.T) {
+	gen := NewIDGenerator(1, WithWordlists([]string{"happy", "sad"}, []string{"otter", "lemur"}))
+
+	seen := map[string]bool{}
+	for i := 0; i < 4; i++ {
+		id := gen.Next()
+		if seen[id] {
+			t.Fatalf("got duplicate id %q within the first 4 calls (pool has 4 pairs)", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestIDGeneratorFallsBackToNumericSuffixOnOverflow(t *testing.T) {
+	gen := NewIDGenerator(1, WithWordlists([]string{"happy"}, []string{"otter"}))
+
+	first := gen.Next()
+	if first != "happy-otter" {
+		t.Fatalf("expected the single pair %q, got %q", "happy-otter", first)
+	}
+
+	for i := 0; i < 3; i++ {
+		id := gen.Next()
+		if !strings.HasPrefix(id, "happy-otter-") {
+			t.Fatalf("expected a numeric-suffixed id once the pool is exhausted, got %q", id)
+		}
+		if id == first {
+			t.Fatalf("overflow id %q collided with the original pair", id)
+		}
+	}
+}
+
+func TestIDGeneratorEmptyWordlistsPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected NewIDGenerator to panic on an empty wordlist pool")
+		}
+	}()
+	NewIDGenerator(1, WithWordlists(nil, nil))
+}
+
+func writeWordlistFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadWordlistJSON(t *testing.T) {
+	path := writeWordlistFile(t, "words.json", `{"adjectives":["Speedy"],"animals":["Ferret"]}`)
+
+	wl, err := LoadWordlist(path)
+	if err != nil {
+		t.Fatalf("LoadWordlist: %v", err)
+	}
+	if len(wl.Adjectives) != 1 || wl.Adjectives[0] != "Speedy" {
+		t.Fatalf("unexpected adjectives: %v", wl.Adjectives)
+	}
+	if len(wl.Animals) != 1 || wl.Animals[0] != "Ferret" {
+		t.Fatalf("unexpected animals: %v", wl.Animals)
+	}
+}
+
+func TestLoadWordlistYAML(t *testing.T) {
+	path := writeWordlistFile(t, "words.yaml", "adjectives: [Speedy]\nanimals: [Ferret]\n")
+
+	wl, err := LoadWordlist(path)
+	if err != nil {
+		t.Fatalf("LoadWordlist: %v", err)
+	}
+	if len(wl.Adjectives) != 1 || len(wl.Animals) != 1 {
+		t.Fatalf("unexpected wordlist: %+v", wl)
+	}
+}
+
+func TestLoadWordlistRejectsIncompletePool(t *testing.T) {
+	path := writeWordlistFile(t, "words.json", `{"adjectives":["Speedy"],"animals":[]}`)
+
+	if _, err := LoadWordlist(path); err == nil {
+		t.Fatal("expected an error when animals is empty, got nil")
+	}
+}
+
+func TestLoadWordlistRejectsUnknownExtension(t *testing.T) {
+	path := writeWordlistFile(t, "words.txt", `adjectives: [Speedy]`)
+
+	if _, err := LoadWordlist(path); err == nil {
+		t.Fatal("expected an error for an unrecognized extension, got nil")
+	}
+}
+
+func TestResolveIDOptsEmptyPath(t *testing.T) {
+	opts, err := resolveIDOpts("")
+	if err != nil {
+		t.Fatalf("resolveIDOpts: %v", err)
+	}
+	if opts != nil {
+		t.Fatalf("expected no options for an empty path, got %v", opts)
+	}
+}
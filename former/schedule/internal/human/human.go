@@ -0,0 +1,46 @@
+// Package human formats values for interactive terminal output: durations
+// like "3h15m", large counts like "1.2k", and ratios as percentages.
+package human
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Duration formats a duration the way a person would write it by hand,
+// e.g. "3h15m", "45m", or "2h".
+func Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// Count abbreviates large counts, e.g. 1234 -> "1.2k".
+func Count(n int) string {
+	switch abs := n; {
+	case abs >= 1_000_000 || abs <= -1_000_000:
+		return fmt.Sprintf("%.1fm", float64(n)/1_000_000)
+	case abs >= 1_000 || abs <= -1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// Percent formats a ratio (1.0 == 100%) as a whole-number percentage.
+func Percent(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", ratio*100)
+}
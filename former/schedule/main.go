@@ -2,140 +2,282 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"math"
-	"math/rand"
 	"os"
-	"regexp"
 	"strings"
 	"time"
 )
 
-var ODH = map[string]float64{
-	"morning": 3.0,
-	"midday":  4.0,
-	"evening": 5.0,
-	"night":   3.0,
+// usage is printed when no subcommand is recognized.
+const usage = `usage:
+  schedule -config <profile> [-tz <zone>] [-date <date>]...   compute required workers and simulate availability from a forecast
+  schedule simulate -config <profile> -date <date>...         generate a standalone simulated worker pool
+`
+
+// dateList collects repeated -date flags (and comma-separated values within
+// a single occurrence) into an ordered, deduplicated list of dates.
+type dateList struct {
+	dates []string
+	seen  map[string]bool
 }
 
-var START = map[string]string{
-	"morning": "07:00",
-	"midday":  "11:00",
-	"evening": "15:00",
-	"night":   "19:00",
+func (d *dateList) String() string {
+	return strings.Join(d.dates, ",")
 }
 
-var END = map[string]string{
-	"morning": "10:59",
-	"midday":  "14:59",
-	"evening": "18:59",
-	"night":   "22:59",
+func (d *dateList) Set(value string) error {
+	if d.seen == nil {
+		d.seen = map[string]bool{}
+	}
+	for _, date := range strings.Split(value, ",") {
+		date = strings.TrimSpace(date)
+		if date == "" || d.seen[date] {
+			continue
+		}
+		d.seen[date] = true
+		d.dates = append(d.dates, date)
+	}
+	return nil
 }
 
 func main() {
-	var in input
-	if err := json.NewDecoder(os.Stdin).Decode(&in); err != nil {
-		panic(err)
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		runSimulate(os.Args[2:])
+		return
+	}
+	runSchedule(os.Args[1:])
+}
+
+func runSchedule(args []string) {
+	fs := flag.NewFlagSet("schedule", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage); fs.PrintDefaults() }
+	configPath := fs.String("config", "", "path to a YAML or TOML shift profile")
+	tz := fs.String("tz", "America/New_York", "default IANA timezone, overridden by the profile or a block's own timezone")
+	workersConfigPath := fs.String("workers-config", "", "path to a YAML or TOML simulation profile for the generated worker pool (defaults to a built-in archetype mix)")
+	seed := fs.Int64("seed", 0, "override the simulation profile's seed; 0 keeps the profile's own seed")
+	query := fs.String("query", "", "gjson path evaluated against the raw input to select forecast blocks, e.g. solutions.0.#(date==\"2018-11-15\")#; defaults to solutions.0")
+	queryFile := fs.String("query-file", "", "path to a file containing a gjson path, for queries too long or reused too often to pass inline")
+	format := fs.String("format", "json", "output format: json, text, or table")
+	wordlistPath := fs.String("wordlist", "", "path to a JSON or YAML file with custom adjective/animal wordlists for worker IDs (defaults to the built-in wordlists)")
+	var dates dateList
+	fs.Var(&dates, "date", "date to schedule (YYYY-MM-DD); repeatable or comma-separated, defaults to every date matched by the query")
+	fs.Parse(args)
+
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "error: -config is required (path to a YAML or TOML shift profile)")
+		os.Exit(1)
 	}
 
-	newYork, err := time.LoadLocation("America/New_York")
+	idOpts, err := resolveIDOpts(*wordlistPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+	profile, err := LoadProfile(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	q, err := resolveQuery(*query, *queryFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	raw, err := io.ReadAll(os.Stdin)
 	if err != nil {
 		panic(err)
 	}
 
-	solution := in.Solutions[0]
+	solution, err := resolveBlocks(raw, q)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	wantDate := map[string]bool{}
+	for _, date := range dates.dates {
+		wantDate[date] = true
+	}
 
 	blocks := []forecast{}
 	for _, block := range solution {
-		if block.Date == os.Args[1] {
+		if len(wantDate) == 0 || wantDate[block.Date] {
 			blocks = append(blocks, block)
 		}
 	}
 
 	out := output{}
+	reports := []blockReport{}
 
-	dates := []string{}
+	scheduleDates := []string{}
 	seen := map[string]bool{}
 
 	for _, block := range blocks {
 		if !seen[block.Date] {
-			dates = append(dates, block.Date)
+			scheduleDates = append(scheduleDates, block.Date)
 			seen[block.Date] = true
 		}
 
+		blockTZ := profile.TimezoneFor(block.Block)
+		if blockTZ == "" {
+			blockTZ = *tz
+		}
+		loc, err := time.LoadLocation(blockTZ)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+
+		weekday, err := time.ParseInLocation("2006-01-02", block.Date, loc)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+
+		startHM, endHM, ordersPerHour, err := profile.Resolve(block.Block, weekday.Weekday().String())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s (date %s)\n", err, block.Date)
+			os.Exit(1)
+		}
+
 		start, err := time.ParseInLocation(
 			"2006-01-02 15:04",
-			fmt.Sprintf("%s %s", block.Date, START[block.Block]),
-			newYork,
+			fmt.Sprintf("%s %s", block.Date, startHM),
+			loc,
 		)
 		if err != nil {
-			panic(err)
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
 		}
 		end, err := time.ParseInLocation(
 			"2006-01-02 15:04",
-			fmt.Sprintf("%s %s", block.Date, END[block.Block]),
-			newYork,
+			fmt.Sprintf("%s %s", block.Date, endHM),
+			loc,
 		)
 		if err != nil {
-			panic(err)
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
 		}
 
+		requiredCount := int(math.Round(block.Forecast / ordersPerHour))
 		out.RequiredWorkers = append(
 			out.RequiredWorkers,
 			requiredWorkers{
 				Start: start.Format(time.RFC3339),
 				End:   end.Format(time.RFC3339),
-				Count: int(math.Round(block.Forecast / ODH[block.Block])),
+				Count: requiredCount,
 			},
 		)
+		reports = append(reports, blockReport{
+			Date:          block.Date,
+			Block:         block.Block,
+			Start:         start,
+			End:           end,
+			RequiredCount: requiredCount,
+		})
+	}
+
+	workersLoc, err := time.LoadLocation(*tz)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	simCfg := defaultSimConfig()
+	if *workersConfigPath != "" {
+		loaded, err := LoadSimConfig(*workersConfigPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
+		}
+		simCfg = loaded
+	}
+	if *seed != 0 {
+		simCfg.Seed = *seed
+	}
+
+	workers, err := NewSimulator(simCfg, idOpts...).Generate(scheduleDates, workersLoc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
 	}
+	out.Workers = workers
 
-	for i := 0; i < 100; i++ {
-		shifts := []shift{}
-		for _, date := range dates {
-			if rand.Float64() < 0.25 {
-				continue
-			}
-			start := rand.Intn(17) + 7
-			end := rand.Intn(17) + 7
-			if start > end {
-				start, end = end, start
-			}
-			startS, err := time.ParseInLocation(
-				"2006-01-02 15:04",
-				fmt.Sprintf("%s %d:00", date, start),
-				newYork,
-			)
-			if err != nil {
-				panic(err)
-			}
-
-			endS, err := time.ParseInLocation(
-				"2006-01-02 15:04",
-				fmt.Sprintf("%s %d:59", date, end),
-				newYork,
-			)
-			if err != nil {
-				panic(err)
-			}
-
-			shifts = append(shifts, shift{
-				Start: startS.Format(time.RFC3339),
-				End:   endS.Format(time.RFC3339),
-			})
+	if *format == "json" {
+		json.NewEncoder(os.Stdout).Encode(out)
+		return
+	}
+
+	for i := range reports {
+		count, err := countCoveringWorkers(workers, reports[i].Start, reports[i].End)
+		if err != nil {
+			panic(err)
 		}
+		reports[i].SimulatedCount = count
+	}
+	if err := writeReport(os.Stdout, *format, reports); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
 
-		if len(shifts) < 1 {
-			continue
+func runSimulate(args []string) {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage); fs.PrintDefaults() }
+	configPath := fs.String("config", "", "path to a YAML or TOML simulation profile (archetypes, seed, worker count); defaults to a built-in archetype mix")
+	tz := fs.String("tz", "America/New_York", "IANA timezone the generated availability windows are expressed in")
+	seed := fs.Int64("seed", 0, "override the simulation profile's seed; 0 keeps the profile's own seed")
+	wordlistPath := fs.String("wordlist", "", "path to a JSON or YAML file with custom adjective/animal wordlists for worker IDs (defaults to the built-in wordlists)")
+	var dates dateList
+	fs.Var(&dates, "date", "date to simulate availability for (YYYY-MM-DD); repeatable or comma-separated")
+	fs.Parse(args)
+
+	if len(dates.dates) == 0 {
+		fmt.Fprintln(os.Stderr, "error: simulate requires at least one -date")
+		os.Exit(1)
+	}
+
+	idOpts, err := resolveIDOpts(*wordlistPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	cfg := defaultSimConfig()
+	if *configPath != "" {
+		loaded, err := LoadSimConfig(*configPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(1)
 		}
+		cfg = loaded
+	}
+	if *seed != 0 {
+		cfg.Seed = *seed
+	}
 
-		out.Workers = append(out.Workers, worker{
-			Availability: shifts,
-			ID:           workerID(),
-		})
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
 	}
 
-	json.NewEncoder(os.Stdout).Encode(out)
+	workers, err := NewSimulator(cfg, idOpts...).Generate(dates.dates, loc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	json.NewEncoder(os.Stdout).Encode(simulateOutput{Workers: workers})
+}
+
+type simulateOutput struct {
+	Workers []worker `json:"workers"`
 }
 
 type input struct {
@@ -168,27 +310,3 @@ type requiredWorkers struct {
 	End   string `json:"end"`
 	Count int    `json:"count"`
 }
-
-var adjectives = []string{"Recalcitrant", "Beneficent", "Flabbergasted", "Loquacious", "Mellifluous", "Cantankerous", "Effervescent", "Quixotic", "Gregarious", "Lugubrious", "Obstreperous", "Perspicacious", "Rambunctious", "Sesquipedalian", "Vivacious", "Whimsical", "Zany", "Astonishing", "Bombastic", "Cacophonous", "Dapper", "Ebullient", "Farcical", "Gibbous", "Hapless", "Ineffable", "Jocular", "Kaleidoscopic", "Lachrymose", "Munificent", "Nebulous", "Omnipotent", "Pernicious", "Querulous", "Rapscallion", "Supercilious", "Turbulent", "Ubiquitous", "Vainglorious", "Wanderlust", "Xenophilic", "Yawning", "Zealous", "Aberrant", "Bucolic", "Cryptic", "Delirious", "Enigmatic", "Frivolous", "Ghastly"}
-var animals = []string{"Lemur", "Hippopotamus", "Platypus", "Sloth", "Armadillo", "Kangaroo", "Penguin", "Otter", "Meerkat", "Wombat", "Koala", "Chameleon", "Pangolin", "Ostrich", "Flamingo", "Narwhal", "Toucan", "Aardvark", "Porcupine", "Walrus", "Capuchin Monkey", "Bush Baby", "Giraffe", "Okapi", "Red Panda", "Fennec Fox", "Axolotl", "Tarsier", "Sugar Glider", "Quokka", "Blobfish", "Peacock", "Mandrill", "Proboscis Monkey", "Dik-dik", "Jerboa", "Alpaca", "Flying Squirrel", "Sea Otter", "Manatee", "Kakapo", "Binturong", "Tapir", "Guinea Pig", "Hedgehog", "Star-Nosed Mole", "Turtle", "Chinchilla", "Naked Mole Rat"}
-
-func toSlug(s string) string {
-	slug := strings.ToLower(s)
-	reg := regexp.MustCompile("[^a-z0-9-]+")
-	slug = reg.ReplaceAllString(slug, "-")
-	return strings.Trim(slug, "-")
-}
-
-var seenIDs = map[string]bool{}
-
-func workerID() string {
-	for {
-		adjective := adjectives[rand.Intn(len(adjectives))]
-		animal := animals[rand.Intn(len(animals))]
-		id := toSlug(fmt.Sprintf("%s %s", adjective, animal))
-		if !seenIDs[id] {
-			seenIDs[id] = true
-			return id
-		}
-	}
-}
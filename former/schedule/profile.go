@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named shift profile describing the time blocks a forecast can
+// fall into: when each block starts and ends, how many orders a single
+// worker can handle per hour during that block, and the timezone those
+// times should be interpreted in. It replaces the hard-coded ODH/START/END
+// maps so bakery, warehouse, or 24/7 call-center shifts can be modeled
+// without recompiling.
+type Profile struct {
+	// Timezone is the default IANA timezone for every block, e.g.
+	// "America/New_York". A block may override it.
+	Timezone string               `yaml:"timezone" toml:"timezone"`
+	Blocks   map[string]*BlockDef `yaml:"blocks" toml:"blocks"`
+}
+
+// BlockDef describes a single named time block, e.g. "morning".
+type BlockDef struct {
+	Start         string                  `yaml:"start" toml:"start"`
+	End           string                  `yaml:"end" toml:"end"`
+	OrdersPerHour float64                 `yaml:"orders_per_hour" toml:"orders_per_hour"`
+	Timezone      string                  `yaml:"timezone" toml:"timezone"`
+	Overrides     map[string]*DayOverride `yaml:"overrides" toml:"overrides"`
+}
+
+// DayOverride replaces one or more of a block's fields on a given
+// day-of-week, keyed by lowercase English weekday name (e.g. "saturday").
+type DayOverride struct {
+	Start         string  `yaml:"start" toml:"start"`
+	End           string  `yaml:"end" toml:"end"`
+	OrdersPerHour float64 `yaml:"orders_per_hour" toml:"orders_per_hour"`
+}
+
+// LoadProfile reads a shift profile from a YAML or TOML file, selected by
+// the file's extension.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading profile %s: %w", path, err)
+	}
+
+	profile := &Profile{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, profile); err != nil {
+			return nil, fmt.Errorf("parsing profile %s as YAML: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, profile); err != nil {
+			return nil, fmt.Errorf("parsing profile %s as TOML: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized profile extension %q: expected .yaml, .yml, or .toml", ext)
+	}
+
+	if len(profile.Blocks) == 0 {
+		return nil, fmt.Errorf("profile %s declares no blocks", path)
+	}
+	if err := profile.validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// validWeekdays is the set of day names an override key may use, matched
+// case-insensitively against time.Weekday.String() lowercased.
+var validWeekdays = map[string]bool{
+	"sunday": true, "monday": true, "tuesday": true, "wednesday": true,
+	"thursday": true, "friday": true, "saturday": true,
+}
+
+// validate checks that every block, and any day-of-week override that sets
+// orders_per_hour, declares a positive value. A zero or negative value would
+// otherwise silently turn required-worker math into +Inf or a sign flip. It
+// also normalizes override keys to lowercase and rejects anything that
+// isn't a real weekday name, since Resolve looks overrides up by lowercased
+// weekday and a typo'd or mis-cased key would otherwise be a silent no-op.
+func (p *Profile) validate() error {
+	for name, def := range p.Blocks {
+		if def.OrdersPerHour <= 0 {
+			return fmt.Errorf("block %q: orders_per_hour must be positive, got %v", name, def.OrdersPerHour)
+		}
+
+		normalized := make(map[string]*DayOverride, len(def.Overrides))
+		for day, override := range def.Overrides {
+			key := strings.ToLower(day)
+			if !validWeekdays[key] {
+				return fmt.Errorf("block %q: overrides: %q is not a valid weekday name", name, day)
+			}
+			if _, dup := normalized[key]; dup {
+				return fmt.Errorf("block %q: overrides: %q is declared more than once (case-insensitive)", name, day)
+			}
+			if override.OrdersPerHour < 0 {
+				return fmt.Errorf("block %q: overrides.%s: orders_per_hour must be positive, got %v", name, key, override.OrdersPerHour)
+			}
+			normalized[key] = override
+		}
+		def.Overrides = normalized
+	}
+	return nil
+}
+
+// Resolve returns the effective start, end, and orders-per-hour for a named
+// block on the given weekday, applying any day-of-week override on top of
+// the block's base values. It returns an error if the block was never
+// declared in the profile.
+func (p *Profile) Resolve(block string, weekday string) (start, end string, ordersPerHour float64, err error) {
+	def, ok := p.Blocks[block]
+	if !ok {
+		return "", "", 0, fmt.Errorf("block %q is not declared in the shift profile", block)
+	}
+
+	start, end, ordersPerHour = def.Start, def.End, def.OrdersPerHour
+	if override, ok := def.Overrides[strings.ToLower(weekday)]; ok {
+		if override.Start != "" {
+			start = override.Start
+		}
+		if override.End != "" {
+			end = override.End
+		}
+		if override.OrdersPerHour != 0 {
+			ordersPerHour = override.OrdersPerHour
+		}
+	}
+	return start, end, ordersPerHour, nil
+}
+
+// TimezoneFor returns the timezone a block should be interpreted in: the
+// block's own override if set, otherwise the profile default.
+func (p *Profile) TimezoneFor(block string) string {
+	if def, ok := p.Blocks[block]; ok && def.Timezone != "" {
+		return def.Timezone
+	}
+	return p.Timezone
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeProfileFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadProfileRejectsNonPositiveOrdersPerHour(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{
+			name: "zero on the block",
+			contents: `
+timezone: America/New_York
+blocks:
+  morning:
+    start: "08:00"
+    end: "12:00"
+`,
+		},
+		{
+			name: "negative on the block",
+			contents: `
+timezone: America/New_York
+blocks:
+  morning:
+    start: "08:00"
+    end: "12:00"
+    orders_per_hour: -1
+`,
+		},
+		{
+			name: "negative on an override",
+			contents: `
+timezone: America/New_York
+blocks:
+  morning:
+    start: "08:00"
+    end: "12:00"
+    orders_per_hour: 10
+    overrides:
+      saturday:
+        orders_per_hour: -5
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeProfileFile(t, "profile.yaml", tt.contents)
+			if _, err := LoadProfile(path); err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadProfileRejectsInvalidOverrideWeekday(t *testing.T) {
+	path := writeProfileFile(t, "profile.yaml", `
+timezone: America/New_York
+blocks:
+  morning:
+    start: "08:00"
+    end: "12:00"
+    orders_per_hour: 10
+    overrides:
+      Saterday:
+        orders_per_hour: 5
+`)
+	if _, err := LoadProfile(path); err == nil {
+		t.Fatal("expected an error for a misspelled weekday, got nil")
+	}
+}
+
+func TestProfileResolveAppliesMisCasedOverride(t *testing.T) {
+	path := writeProfileFile(t, "profile.yaml", `
+timezone: America/New_York
+blocks:
+  morning:
+    start: "08:00"
+    end: "12:00"
+    orders_per_hour: 10
+    overrides:
+      Saturday:
+        orders_per_hour: 5
+`)
+	profile, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+
+	start, end, ordersPerHour, err := profile.Resolve("morning", "Saturday")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if start != "08:00" || end != "12:00" {
+		t.Fatalf("expected the base start/end to carry through, got %s/%s", start, end)
+	}
+	if ordersPerHour != 5 {
+		t.Fatalf("expected the override orders_per_hour to apply, got %v", ordersPerHour)
+	}
+}
+
+func TestProfileResolveUnknownBlock(t *testing.T) {
+	path := writeProfileFile(t, "profile.yaml", `
+timezone: America/New_York
+blocks:
+  morning:
+    start: "08:00"
+    end: "12:00"
+    orders_per_hour: 10
+`)
+	profile, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+
+	if _, _, _, err := profile.Resolve("evening", "monday"); err == nil {
+		t.Fatal("expected an error for an undeclared block, got nil")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// resolveQuery returns the gjson path to evaluate, taking it either
+// directly from -query or from the file named by -query-file. It is an
+// error to set both.
+func resolveQuery(query, queryFile string) (string, error) {
+	if query != "" && queryFile != "" {
+		return "", fmt.Errorf("-query and -query-file are mutually exclusive")
+	}
+	if queryFile == "" {
+		return query, nil
+	}
+	data, err := os.ReadFile(queryFile)
+	if err != nil {
+		return "", fmt.Errorf("reading -query-file %s: %w", queryFile, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// resolveBlocks picks the forecast blocks to schedule out of the raw input
+// JSON. With no query it falls back to the original behavior of taking the
+// first solution. With a query, it evaluates a gjson path (e.g.
+// `solutions.0.#(date=="2018-11-15")#`) against the raw document and
+// unmarshals whatever it matches, so callers can pick alternate solutions,
+// combine date ranges, or pull from nested formats without pre-processing
+// the JSON themselves.
+func resolveBlocks(raw []byte, query string) ([]forecast, error) {
+	if query == "" {
+		var in input
+		if err := json.Unmarshal(raw, &in); err != nil {
+			return nil, err
+		}
+		if len(in.Solutions) == 0 {
+			return nil, fmt.Errorf("input has no solutions")
+		}
+		return in.Solutions[0], nil
+	}
+
+	result := gjson.GetBytes(raw, query)
+	if !result.Exists() {
+		return nil, fmt.Errorf("query %q matched nothing", query)
+	}
+
+	var blocks []forecast
+	if result.IsArray() {
+		if err := json.Unmarshal([]byte(result.Raw), &blocks); err != nil {
+			return nil, fmt.Errorf("query %q matched data that isn't a list of forecast blocks: %w", query, err)
+		}
+		return blocks, nil
+	}
+
+	var block forecast
+	if err := json.Unmarshal([]byte(result.Raw), &block); err != nil {
+		return nil, fmt.Errorf("query %q matched data that isn't a forecast block: %w", query, err)
+	}
+	return []forecast{block}, nil
+}
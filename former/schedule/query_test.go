@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestResolveBlocksDefaultsToFirstSolution(t *testing.T) {
+	raw := []byte(`{"solutions":[[{"Date":"2018-11-15","Block":"morning","Forecast":50}],[{"Date":"2018-11-16","Block":"evening","Forecast":75}]]}`)
+
+	blocks, err := resolveBlocks(raw, "")
+	if err != nil {
+		t.Fatalf("resolveBlocks: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Date != "2018-11-15" {
+		t.Fatalf("expected the first solution's single block, got %+v", blocks)
+	}
+}
+
+func TestResolveBlocksNoSolutions(t *testing.T) {
+	raw := []byte(`{"solutions":[]}`)
+
+	if _, err := resolveBlocks(raw, ""); err == nil {
+		t.Fatal("expected an error when there are no solutions, got nil")
+	}
+}
+
+func TestResolveBlocksQueryMatchingArray(t *testing.T) {
+	raw := []byte(`{"solutions":[[{"Date":"2018-11-15","Block":"morning","Forecast":50},{"Date":"2018-11-16","Block":"morning","Forecast":60}]]}`)
+
+	blocks, err := resolveBlocks(raw, `solutions.0`)
+	if err != nil {
+		t.Fatalf("resolveBlocks: %v", err)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("expected both blocks in the array, got %+v", blocks)
+	}
+}
+
+func TestResolveBlocksQueryMatchingSingleObject(t *testing.T) {
+	raw := []byte(`{"solutions":[[{"Date":"2018-11-15","Block":"morning","Forecast":50},{"Date":"2018-11-16","Block":"evening","Forecast":60}]]}`)
+
+	blocks, err := resolveBlocks(raw, `solutions.0.#(Date=="2018-11-16")`)
+	if err != nil {
+		t.Fatalf("resolveBlocks: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Block != "evening" {
+		t.Fatalf("expected the single matched block, got %+v", blocks)
+	}
+}
+
+func TestResolveBlocksQueryMatchesNothing(t *testing.T) {
+	raw := []byte(`{"solutions":[[{"Date":"2018-11-15","Block":"morning","Forecast":50}]]}`)
+
+	if _, err := resolveBlocks(raw, `solutions.0.#(Date=="2099-01-01")`); err == nil {
+		t.Fatal("expected an error when the query matches nothing, got nil")
+	}
+}
+
+func TestResolveQueryMutuallyExclusiveWithQueryFile(t *testing.T) {
+	if _, err := resolveQuery("solutions.0", "/tmp/does-not-matter"); err == nil {
+		t.Fatal("expected an error when both -query and -query-file are set, got nil")
+	}
+}
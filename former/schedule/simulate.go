@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Archetype describes one recurring staffing pattern, e.g. full-time
+// employees or weekend-only help, and the weight with which workers are
+// drawn from it.
+type Archetype struct {
+	Name             string  `yaml:"name" toml:"name"`
+	Weight           float64 `yaml:"weight" toml:"weight"`
+	ShiftHoursMin    float64 `yaml:"shift_hours_min" toml:"shift_hours_min"`
+	ShiftHoursMax    float64 `yaml:"shift_hours_max" toml:"shift_hours_max"`
+	Pattern          string  `yaml:"pattern" toml:"pattern"`
+	MaxShiftsPerWeek int     `yaml:"max_shifts_per_week" toml:"max_shifts_per_week"`
+	MinRestHours     float64 `yaml:"min_rest_hours" toml:"min_rest_hours"`
+}
+
+// SimConfig is a simulation profile: how many workers to generate, a seed
+// for reproducibility, and the archetype mix to sample them from.
+type SimConfig struct {
+	Seed       int64       `yaml:"seed" toml:"seed"`
+	Workers    int         `yaml:"workers" toml:"workers"`
+	Archetypes []Archetype `yaml:"archetypes" toml:"archetypes"`
+}
+
+// LoadSimConfig reads a simulation profile from a YAML or TOML file,
+// selected by the file's extension.
+func LoadSimConfig(path string) (*SimConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading simulation profile %s: %w", path, err)
+	}
+
+	cfg := &SimConfig{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing simulation profile %s as YAML: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing simulation profile %s as TOML: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized simulation profile extension %q: expected .yaml, .yml, or .toml", ext)
+	}
+
+	if len(cfg.Archetypes) == 0 {
+		return nil, fmt.Errorf("simulation profile %s declares no archetypes", path)
+	}
+	return cfg, nil
+}
+
+// defaultSimConfig is the archetype mix used when no -workers-config is
+// given, roughly modeling a typical retail staff: mostly full-time, a
+// part-time evening shift, and a small weekend-only pool.
+func defaultSimConfig() *SimConfig {
+	return &SimConfig{
+		Seed:    1,
+		Workers: 100,
+		Archetypes: []Archetype{
+			{Name: "full-time", Weight: 0.6, ShiftHoursMin: 8, ShiftHoursMax: 8, Pattern: "full-time", MaxShiftsPerWeek: 5, MinRestHours: 12},
+			{Name: "part-time-evenings", Weight: 0.3, ShiftHoursMin: 4, ShiftHoursMax: 6, Pattern: "part-time-evenings", MaxShiftsPerWeek: 4, MinRestHours: 10},
+			{Name: "weekend-only", Weight: 0.1, ShiftHoursMin: 6, ShiftHoursMax: 8, Pattern: "weekend-only", MaxShiftsPerWeek: 2, MinRestHours: 24},
+		},
+	}
+}
+
+// Simulator generates worker availability from a SimConfig using a seeded
+// random source, so runs are reproducible given the same config and seed.
+type Simulator struct {
+	cfg *SimConfig
+	rng *rand.Rand
+	ids *IDGenerator
+}
+
+// NewSimulator builds a Simulator seeded from cfg.Seed. Any IDGeneratorOption
+// (e.g. WithWordlists) is passed through to the underlying IDGenerator.
+func NewSimulator(cfg *SimConfig, idOpts ...IDGeneratorOption) *Simulator {
+	return &Simulator{
+		cfg: cfg,
+		rng: rand.New(rand.NewSource(cfg.Seed)),
+		ids: NewIDGenerator(cfg.Seed, idOpts...),
+	}
+}
+
+// pickArchetype draws a weighted-random archetype from the config.
+func (s *Simulator) pickArchetype() Archetype {
+	total := 0.0
+	for _, a := range s.cfg.Archetypes {
+		total += a.Weight
+	}
+
+	r := s.rng.Float64() * total
+	for _, a := range s.cfg.Archetypes {
+		if r < a.Weight {
+			return a
+		}
+		r -= a.Weight
+	}
+	return s.cfg.Archetypes[len(s.cfg.Archetypes)-1]
+}
+
+// allowedWeekdays returns the days of the week an archetype's pattern is
+// available to work.
+func allowedWeekdays(pattern string) (map[time.Weekday]bool, error) {
+	weekdaySet := func(days ...time.Weekday) map[time.Weekday]bool {
+		set := map[time.Weekday]bool{}
+		for _, d := range days {
+			set[d] = true
+		}
+		return set
+	}
+
+	switch pattern {
+	case "full-time", "part-time-evenings":
+		return weekdaySet(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday), nil
+	case "weekend-only":
+		return weekdaySet(time.Saturday, time.Sunday), nil
+	default:
+		return nil, fmt.Errorf("unknown availability pattern %q", pattern)
+	}
+}
+
+// startHourRange returns the range of hours a shift may begin in, given a
+// pattern. Evening patterns start later in the day than day-shift patterns.
+func startHourRange(pattern string) (min, max int) {
+	if pattern == "part-time-evenings" {
+		return 15, 19
+	}
+	return 6, 14
+}
+
+// Generate produces simulated availability for each worker across dates,
+// respecting the archetype's weekly pattern, shift-length distribution,
+// max shifts per week, and minimum rest between shifts. Workers with no
+// availability in the given dates are omitted.
+func (s *Simulator) Generate(dates []string, loc *time.Location) ([]worker, error) {
+	// MinRestHours and the prevEnd check below assume dates are processed
+	// chronologically, but callers may pass them in flag or query order.
+	sorted := append([]string(nil), dates...)
+	sort.Strings(sorted)
+	dates = sorted
+
+	workers := []worker{}
+
+	for i := 0; i < s.cfg.Workers; i++ {
+		archetype := s.pickArchetype()
+		allowed, err := allowedWeekdays(archetype.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("archetype %q: %w", archetype.Name, err)
+		}
+		minHour, maxHour := startHourRange(archetype.Pattern)
+
+		shifts := []shift{}
+		shiftsInWeek := map[int]int{}
+		var prevEnd time.Time
+
+		for _, date := range dates {
+			day, err := time.ParseInLocation("2006-01-02", date, loc)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed[day.Weekday()] {
+				continue
+			}
+
+			_, week := day.ISOWeek()
+			if shiftsInWeek[week] >= archetype.MaxShiftsPerWeek {
+				continue
+			}
+
+			startHour := minHour + s.rng.Intn(maxHour-minHour+1)
+			start := time.Date(day.Year(), day.Month(), day.Day(), startHour, 0, 0, 0, loc)
+			if !prevEnd.IsZero() && start.Sub(prevEnd) < time.Duration(archetype.MinRestHours*float64(time.Hour)) {
+				continue
+			}
+
+			hours := archetype.ShiftHoursMin + s.rng.Float64()*(archetype.ShiftHoursMax-archetype.ShiftHoursMin)
+			end := start.Add(time.Duration(hours * float64(time.Hour)))
+
+			shifts = append(shifts, shift{Start: start.Format(time.RFC3339), End: end.Format(time.RFC3339)})
+			shiftsInWeek[week]++
+			prevEnd = end
+		}
+
+		if len(shifts) < 1 {
+			continue
+		}
+		workers = append(workers, worker{Availability: shifts, ID: s.ids.Next()})
+	}
+
+	return workers, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerateSortsDatesChronologically(t *testing.T) {
+	cfg := &SimConfig{
+		Seed:    5,
+		Workers: 30,
+		Archetypes: []Archetype{
+			{Name: "full-time", Weight: 1, ShiftHoursMin: 8, ShiftHoursMax: 8, Pattern: "full-time", MaxShiftsPerWeek: 5, MinRestHours: 12},
+		},
+	}
+
+	unsorted := []string{"2018-11-20", "2018-11-15", "2018-11-16"}
+	sorted := []string{"2018-11-15", "2018-11-16", "2018-11-20"}
+
+	got, err := NewSimulator(cfg).Generate(unsorted, time.UTC)
+	if err != nil {
+		t.Fatalf("Generate(unsorted): %v", err)
+	}
+	want, err := NewSimulator(cfg).Generate(sorted, time.UTC)
+	if err != nil {
+		t.Fatalf("Generate(sorted): %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatal("Generate produced different results for an unsorted date list than its sorted equivalent")
+	}
+
+	multiShift := 0
+	for _, w := range got {
+		if len(w.Availability) > 1 {
+			multiShift++
+		}
+	}
+	if multiShift == 0 {
+		t.Fatal("expected at least one worker to have more than one shift across three eligible weekdays")
+	}
+}
+
+func TestGenerateEnforcesMinRestHours(t *testing.T) {
+	cfg := &SimConfig{
+		Seed:    1,
+		Workers: 10,
+		Archetypes: []Archetype{
+			{Name: "full-time", Weight: 1, ShiftHoursMin: 8, ShiftHoursMax: 8, Pattern: "full-time", MaxShiftsPerWeek: 5, MinRestHours: 200},
+		},
+	}
+	dates := []string{"2018-11-12", "2018-11-13", "2018-11-14", "2018-11-15", "2018-11-16", "2018-11-19", "2018-11-20"}
+
+	workers, err := NewSimulator(cfg).Generate(dates, time.UTC)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, w := range workers {
+		if len(w.Availability) > 1 {
+			t.Fatalf("worker %s got %d shifts; a 200h min-rest requirement should allow at most one across this span", w.ID, len(w.Availability))
+		}
+	}
+}
+
+func TestGenerateEnforcesMaxShiftsPerWeek(t *testing.T) {
+	cfg := &SimConfig{
+		Seed:    2,
+		Workers: 10,
+		Archetypes: []Archetype{
+			{Name: "full-time", Weight: 1, ShiftHoursMin: 1, ShiftHoursMax: 1, Pattern: "full-time", MaxShiftsPerWeek: 2, MinRestHours: 0},
+		},
+	}
+	// All five weekdays fall in ISO week 46 of 2018.
+	dates := []string{"2018-11-12", "2018-11-13", "2018-11-14", "2018-11-15", "2018-11-16"}
+
+	workers, err := NewSimulator(cfg).Generate(dates, time.UTC)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, w := range workers {
+		shiftsInWeek := map[int]int{}
+		for _, s := range w.Availability {
+			start, err := time.Parse(time.RFC3339, s.Start)
+			if err != nil {
+				t.Fatalf("parsing shift start %q: %v", s.Start, err)
+			}
+			_, week := start.ISOWeek()
+			shiftsInWeek[week]++
+		}
+		for week, count := range shiftsInWeek {
+			if count > 2 {
+				t.Fatalf("worker %s got %d shifts in ISO week %d, want at most 2", w.ID, count, week)
+			}
+		}
+	}
+}